@@ -7,6 +7,10 @@ import (
 	"errors"
 	"github.com/giantswarm/validate"
 	"github.com/inhies/go-tld"
+	"golang.org/x/net/idna"
+	"golang.org/x/text/secure/bidirule"
+	"golang.org/x/text/unicode/norm"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 )
@@ -21,6 +25,18 @@ type Domain struct {
 	domain  []byte
 	message string
 	checks  map[string]interface{}
+
+	// ascii holds the A-label (punycode) form once IDNA processing has
+	// run. unicode holds the human-readable Unicode form produced by IDNA;
+	// the original input stays in domain. Both are only populated when IDNA
+	// mode is enabled.
+	ascii   []byte
+	unicode []byte
+
+	// Populated by classifyPSL when PSL-aware classification is enabled.
+	publicSuffix string
+	registrable  string
+	subdomains   []string
 }
 
 // Return the domain
@@ -28,6 +44,24 @@ func (d *Domain) String() string {
 	return string(d.domain)
 }
 
+// Return the A-label (punycode) form of the domain. Only meaningful after
+// Validate has run with IDNA mode enabled; otherwise it mirrors String().
+func (d *Domain) ASCII() string {
+	if d.ascii != nil {
+		return string(d.ascii)
+	}
+	return d.String()
+}
+
+// Return the Unicode form of the domain. Only meaningful after Validate has
+// run with IDNA mode enabled; otherwise it mirrors String().
+func (d *Domain) Unicode() string {
+	if d.unicode != nil {
+		return string(d.unicode)
+	}
+	return d.String()
+}
+
 // Create a new domain value to be validated
 func NewDomain(domain string) *Domain {
 	d := Domain{
@@ -66,6 +100,93 @@ func (d *Domain) MaxLength(m int) *Domain {
 	return d
 }
 
+// Enables internationalized domain name (IDN) support, running the input
+// through the given UTS #46 profile before applying the usual label and
+// length checks. Use idna.Lookup, idna.Registration, or idna.Display (from
+// golang.org/x/net/idna) for the standard presets; idna.Display will
+// validate against the Unicode form instead of the A-label form.
+func (d *Domain) IDNA(profile *idna.Profile) *Domain {
+	d.checks["idna"] = profile
+	return d
+}
+
+// Disables IDNA processing and requires the domain to already be in plain
+// ASCII (LDH) form. This is the default behavior, so ASCIIOnly only matters
+// to undo a prior call to IDNA.
+func (d *Domain) ASCIIOnly() *Domain {
+	delete(d.checks, "idna")
+	return d
+}
+
+// Allows (or disallows) a single trailing dot, as in "example.com.", per the
+// RFC 1034 FQDN convention. When a trailing dot is present the 254-octet cap
+// applies instead of the usual 253.
+func (d *Domain) AllowTrailingDot(allow bool) *Domain {
+	d.checks["trailingdot"] = allow
+	return d
+}
+
+// Allows a single leading wildcard label, as in "*.example.com", for
+// certificate-style names. The "*" must be the only character in the
+// leftmost label.
+func (d *Domain) AllowWildcard() *Domain {
+	d.checks["wildcard"] = true
+	return d
+}
+
+// Rejects domains that are themselves a bare public suffix, e.g. "co.uk" or
+// "github.io" — domains nobody could actually register. Implies PSL
+// classification.
+func (d *Domain) RequireRegistrable() *Domain {
+	d.checks["requireregistrable"] = true
+	return d
+}
+
+// Rejects domains whose public suffix comes from the PSL's PRIVATE DOMAINS
+// section (e.g. "github.io") rather than ICANN's managed TLDs. Implies PSL
+// classification.
+func (d *Domain) ForbidPrivateSuffix() *Domain {
+	d.checks["forbidprivate"] = true
+	return d
+}
+
+// Overrides the PSLSource used for public-suffix classification. Defaults to
+// DefaultPSLSource.
+func (d *Domain) WithPSLSource(src PSLSource) *Domain {
+	d.checks["pslsource"] = src
+	return d
+}
+
+// Overrides the TLDSource used to decide whether the TLD label is
+// recognized. Defaults to IANASource{}, which is the package-level IANA
+// list also used by the legacy TLDs/IANA/UpdateTLDs globals.
+func (d *Domain) WithTLDSource(src TLDSource) *Domain {
+	d.checks["tldsource"] = src
+	return d
+}
+
+// Returns the registrable domain (eTLD+1), e.g. "example.co.uk" for
+// "www.example.co.uk". Only populated after Validate has run with PSL
+// classification enabled (via RequireRegistrable, ForbidPrivateSuffix, or
+// WithPSLSource).
+func (d *Domain) RegistrableDomain() string {
+	return d.registrable
+}
+
+// Returns the public suffix, e.g. "co.uk" for "www.example.co.uk". Only
+// populated after Validate has run with PSL classification enabled.
+func (d *Domain) PublicSuffix() string {
+	return d.publicSuffix
+}
+
+// Returns the labels between the registrable domain and the full domain,
+// in the order they appear in the name (leftmost first), e.g. ["a", "b"]
+// for "a.b.example.co.uk". Only populated after Validate has run with PSL
+// classification enabled.
+func (d *Domain) Subdomains() []string {
+	return d.subdomains
+}
+
 var (
 	// A-Z, a-z, 0-9, and hyphen are the only valid characters for domains.
 	domainTable = &unicode.RangeTable{
@@ -96,6 +217,42 @@ var (
 		ErrLevel: 2,
 		Message:  errors.New("Unknown error"),
 	}
+
+	// The domain failed IDNA processing for the configured profile.
+	ErrIDNAProfile = &validate.ValidatorError{
+		ErrLevel: validate.ErrInvalid,
+		Message:  errors.New("Invalid internationalized domain name"),
+	}
+
+	// The domain violates the bidirectional text rule required by UTS #46.
+	ErrBidiRule = &validate.ValidatorError{
+		ErrLevel: validate.ErrInvalid,
+		Message:  errors.New("Violates bidi rule"),
+	}
+
+	// The TLD label is entirely numeric, which RFC 3696 §2 disallows.
+	ErrNumericTLD = &validate.ValidatorError{
+		ErrLevel: validate.ErrInvalid,
+		Message:  errors.New("TLD cannot be all numeric"),
+	}
+
+	// A "*" appeared somewhere other than alone in the leftmost label.
+	ErrWildcardPlacement = &validate.ValidatorError{
+		ErrLevel: validate.ErrInvalid,
+		Message:  errors.New("Wildcard must be the sole leftmost label"),
+	}
+
+	// The domain is itself a bare public suffix, e.g. "co.uk".
+	ErrNotRegistrable = &validate.ValidatorError{
+		ErrLevel: validate.ErrInvalid,
+		Message:  errors.New("Domain is a bare public suffix"),
+	}
+
+	// The domain's public suffix is from the PSL's PRIVATE DOMAINS section.
+	ErrPrivateSuffix = &validate.ValidatorError{
+		ErrLevel: validate.ErrInvalid,
+		Message:  errors.New("Domain uses a private public suffix"),
+	}
 )
 
 // Checks for a valid domain name. Checks lengths, characters, and looks for a
@@ -103,7 +260,7 @@ var (
 func (d *Domain) Validate(v validate.Validator) validate.Error {
 	//func IsDomain(p []byte) (res validate.Result) {
 	// Domain rules:
-	// - 255 character total length max
+	// - 253 character total length max (254 with a trailing dot)
 	// - 63 character label max
 	// - 127 sub-domains
 	// - Characters a-z, A-Z, 0-9, and -
@@ -115,12 +272,110 @@ func (d *Domain) Validate(v validate.Validator) validate.Error {
 	// later.
 
 	p := d.domain
+
+	// Set to false when IDNA is configured with idna.Display, which
+	// validates against the human-readable Unicode form rather than the
+	// A-label form; the character-class check below then has to allow
+	// more than the ASCII LDH charset.
+	ldhOnly := true
+
+	wildcard, _ := d.checks["wildcard"].(bool)
+
+	// Run IDNA processing before any other checks so that the rest of
+	// Validate sees the processed form: the LDH-only A-label form, or the
+	// Unicode form under idna.Display.
+	if chk, ok := d.checks["idna"]; ok {
+		profile := chk.(*idna.Profile)
+
+		// A lone leftmost "*" isn't a domain-name fragment IDNA knows how
+		// to process at all: both the bidi rule and idna's own ToASCII/
+		// ToUnicode reject '*' outright. Strip it off before handing the
+		// rest to IDNA and splice it back on once IDNA is done, the same
+		// way the label loop further down special-cases it. A misplaced
+		// wildcard is rejected right here with the same ErrWildcardPlacement
+		// the label loop would eventually report, rather than running it
+		// through IDNA and surfacing an unrelated bidi/profile error.
+		var wildcardPrefix []byte
+		rest := p
+		if bytes.Contains(p, []byte("*")) {
+			if !wildcard || !bytes.HasPrefix(p, []byte("*.")) || bytes.Contains(p[2:], []byte("*")) {
+				return ErrWildcardPlacement
+			}
+			wildcardPrefix = p[:2]
+			rest = p[2:]
+		}
+		withPrefix := func(b []byte) []byte {
+			return append(append([]byte{}, wildcardPrefix...), b...)
+		}
+
+		// Check the bidi rule ourselves, against the raw input labels,
+		// before handing off to the profile. idna's own label error is
+		// opaque, so if we let a bidi violation surface as a profile
+		// error first we could never tell it apart from any other
+		// IDNA validation failure.
+		for _, label := range strings.Split(string(rest), ".") {
+			if label == "" {
+				continue
+			}
+			if !bidirule.ValidString(label) {
+				return ErrBidiRule
+			}
+		}
+
+		display := profile == idna.Display
+		var (
+			out string
+			err error
+		)
+		if display {
+			out, err = profile.ToUnicode(string(rest))
+		} else {
+			out, err = profile.ToASCII(string(rest))
+		}
+		if err != nil {
+			return ErrIDNAProfile
+		}
+		out = norm.NFC.String(out)
+
+		d.unicode = withPrefix([]byte(out))
+		if display {
+			// There's no A-label form without re-running the ASCII
+			// profile; fall back to Lookup for the punycode side.
+			if ascii, aerr := idna.Lookup.ToASCII(out); aerr == nil {
+				d.ascii = withPrefix([]byte(ascii))
+			}
+			p = withPrefix([]byte(out))
+			ldhOnly = false
+		} else {
+			d.ascii = withPrefix([]byte(out))
+			if uni, uerr := profile.ToUnicode(out); uerr == nil {
+				d.unicode = withPrefix([]byte(uni))
+			} else {
+				d.unicode = d.domain
+			}
+			p = withPrefix([]byte(out))
+		}
+	}
+
+	// A bare trailing dot, as in ".", is never valid.
+	if bytes.Equal(p, []byte(".")) {
+		return ErrFormatting
+	}
+
+	// RFC 1034 allows a single trailing dot to mark a fully-qualified
+	// domain name (making the textual cap 254 instead of 253). Strip it
+	// before splitting into labels and measuring length, so the 253
+	// check below is always against the dot-free form either way.
+	if allow, _ := d.checks["trailingdot"].(bool); allow && bytes.HasSuffix(p, []byte(".")) {
+		p = p[:len(p)-1]
+	}
+
 	// If a max length was specified, use it
 	if d.checks["maxlength"] != nil &&
 		utf8.RuneCount(p) > d.checks["maxlength"].(int) {
 		return ErrDomainLength
 
-	} else if utf8.RuneCount(p) > 255 {
+	} else if utf8.RuneCount(p) > 253 {
 		return ErrDomainLength
 	}
 
@@ -138,13 +393,22 @@ func (d *Domain) Validate(v validate.Validator) validate.Error {
 		return ErrDomainLength
 	}
 	// Check each domain for valid characters
-	for _, subDomain := range domain {
+	for idx, subDomain := range domain {
 		length := len(subDomain)
 		// Check for a domain with two periods next to eachother.
 		if length < 1 {
 			return ErrFormatting
 		}
 
+		if bytes.Contains(subDomain, []byte("*")) {
+			if !wildcard || idx != 0 || !bytes.Equal(subDomain, []byte("*")) {
+				return ErrWildcardPlacement
+			}
+			// A lone leftmost "*" is valid as-is; skip the LDH checks
+			// below, which would otherwise reject it.
+			continue
+		}
+
 		// Check 63 character max.
 		if length > 63 {
 			return ErrDomainLength
@@ -176,7 +440,7 @@ func (d *Domain) Validate(v validate.Validator) validate.Error {
 		for i := 0; i < length; {
 			if subDomain[i] < utf8.RuneSelf {
 				// Check if it's a valid domain character
-				if !unicode.Is(domainTable, rune(subDomain[i])) {
+				if ldhOnly && !unicode.Is(domainTable, rune(subDomain[i])) {
 					return ErrFormatting
 				}
 				i++
@@ -188,8 +452,12 @@ func (d *Domain) Validate(v validate.Validator) validate.Error {
 					// This must be a RuneError.
 					return validate.ErrInvalidUTF8
 				}
-				// Check if it's a valid domain character
-				if !unicode.Is(domainTable, r) {
+				// Check if it's a valid domain character. In
+				// ldhOnly mode that means the ASCII LDH set;
+				// otherwise (idna.Display) any decoded rune
+				// that got this far already passed idna's own
+				// Unicode validation.
+				if ldhOnly && !unicode.Is(domainTable, r) {
 					return ErrFormatting
 				}
 				i += size
@@ -198,14 +466,35 @@ func (d *Domain) Validate(v validate.Validator) validate.Error {
 	}
 
 	// We have all valid unicode characters, now make sure the TLD is real.
-	// TODO(inhies): Add check for an all numeric TLD.
 	domainTLD := domain[len(domain)-1]
-	if tld.Valid(domainTLD) {
-		return nil
+	if isAllDigits(domainTLD) {
+		return ErrNumericTLD
+	}
+
+	tldSource, _ := d.checks["tldsource"].(TLDSource)
+	if tldSource == nil {
+		tldSource = IANASource{}
+	}
+	if !tldSource.Valid(domainTLD) {
+		// The TLD is not one the TLD source recognizes.
+		return ErrUnknown
 	}
 
-	// Not sure how we got here, but lets return false just in case.
-	return ErrUnknown
+	if err := d.classifyPSL(p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Reports whether a label consists entirely of ASCII digits.
+func isAllDigits(label []byte) bool {
+	for _, b := range label {
+		if b < '0' || b > '9' {
+			return false
+		}
+	}
+	return true
 }
 
 // Update the included list of TLDs from the given URL.
@@ -0,0 +1,58 @@
+package web
+
+import (
+	"testing"
+
+	"golang.org/x/net/idna"
+)
+
+func Test_Domain_IDNA_Unicode(t *testing.T) {
+	d := NewDomain("münchen.de").IDNA(idna.Lookup)
+	if err := d.Validate(nil); err != nil {
+		t.Fatalf("expected münchen.de to validate, got %v", err)
+	}
+	if d.ASCII() != "xn--mnchen-3ya.de" {
+		t.Errorf("expected A-label xn--mnchen-3ya.de, got %s", d.ASCII())
+	}
+}
+
+func Test_Domain_IDNA_Display(t *testing.T) {
+	// idna.Display validates against the Unicode form, which is not
+	// restricted to the ASCII LDH charset.
+	d := NewDomain("xn--mnchen-3ya.de").IDNA(idna.Display)
+	if err := d.Validate(nil); err != nil {
+		t.Fatalf("expected xn--mnchen-3ya.de to validate under idna.Display, got %v", err)
+	}
+	if d.Unicode() != "münchen.de" {
+		t.Errorf("expected Unicode form münchen.de, got %s", d.Unicode())
+	}
+}
+
+func Test_Domain_IDNA_AllowsWildcard(t *testing.T) {
+	// A lone leftmost "*" isn't subject to the bidi rule at all; the IDNA
+	// bidi pre-check must skip it the same way the later label loop does.
+	d := NewDomain("*.example.com").AllowWildcard().IDNA(idna.Lookup)
+	if err := d.Validate(nil); err != nil {
+		t.Errorf("expected *.example.com to validate under IDNA + AllowWildcard, got %v", err)
+	}
+}
+
+func Test_Domain_IDNA_MisplacedWildcardStillReportsPlacement(t *testing.T) {
+	// Only a lone leftmost "*" gets the IDNA-skip treatment; a second "*"
+	// deeper in the name should still surface the specific placement
+	// error rather than an unrelated bidi/profile failure.
+	d := NewDomain("*.*.example.com").AllowWildcard().IDNA(idna.Lookup)
+	if err := d.Validate(nil); err != ErrWildcardPlacement {
+		t.Errorf("expected ErrWildcardPlacement, got %v", err)
+	}
+}
+
+func Test_Domain_IDNA_RejectsBidiViolation(t *testing.T) {
+	// An RTL label ("ا١1") mixes an Arabic-Indic digit ("١") with a
+	// European digit ("1"), which the bidi rule (RFC 5893 rule 4)
+	// forbids within a single label.
+	d := NewDomain("ا١1.com").IDNA(idna.Lookup)
+	if err := d.Validate(nil); err != ErrBidiRule {
+		t.Errorf("expected ErrBidiRule, got %v", err)
+	}
+}
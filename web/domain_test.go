@@ -0,0 +1,77 @@
+package web
+
+import (
+	"strings"
+	"testing"
+)
+
+// domainOfLength builds a syntactically valid domain (LDH labels under a
+// real ".com" TLD) whose total length is exactly totalLen octets.
+func domainOfLength(totalLen int) string {
+	const labelLen = 50
+	suffix := ".com"
+	remaining := totalLen - len(suffix)
+	var labels []string
+	for remaining > 0 {
+		n := labelLen
+		if n > remaining {
+			n = remaining
+		}
+		labels = append(labels, strings.Repeat("a", n))
+		remaining -= n
+		if remaining > 0 {
+			remaining--
+		}
+	}
+	return strings.Join(labels, ".") + suffix
+}
+
+func Test_Domain_RejectsNumericTLD(t *testing.T) {
+	d := NewDomain("example.123")
+	if err := d.Validate(nil); err != ErrNumericTLD {
+		t.Errorf("expected ErrNumericTLD, got %v", err)
+	}
+}
+
+func Test_Domain_TrailingDot(t *testing.T) {
+	d := NewDomain("example.com.").AllowTrailingDot(true)
+	if err := d.Validate(nil); err != nil {
+		t.Errorf("expected example.com. to validate, got %v", err)
+	}
+}
+
+func Test_Domain_TrailingDotRejectedByDefault(t *testing.T) {
+	d := NewDomain("example.com.")
+	if err := d.Validate(nil); err == nil {
+		t.Error("expected example.com. to be rejected without AllowTrailingDot")
+	}
+}
+
+func Test_Domain_RejectsBareDot(t *testing.T) {
+	d := NewDomain(".").AllowTrailingDot(true)
+	if err := d.Validate(nil); err != ErrFormatting {
+		t.Errorf("expected ErrFormatting for bare \".\", got %v", err)
+	}
+}
+
+func Test_Domain_TrailingDot_AllowsExactly254WithDot(t *testing.T) {
+	name := domainOfLength(253)
+	if len(name) != 253 {
+		t.Fatalf("test fixture broken: want 253 octets, got %d", len(name))
+	}
+	d := NewDomain(name + ".").AllowTrailingDot(true)
+	if err := d.Validate(nil); err != nil {
+		t.Errorf("expected a 254-octet (with trailing dot) domain to validate, got %v", err)
+	}
+}
+
+func Test_Domain_TrailingDot_Rejects255WithDot(t *testing.T) {
+	name := domainOfLength(254)
+	if len(name) != 254 {
+		t.Fatalf("test fixture broken: want 254 octets, got %d", len(name))
+	}
+	d := NewDomain(name + ".").AllowTrailingDot(true)
+	if err := d.Validate(nil); err != ErrDomainLength {
+		t.Errorf("expected a 255-octet (with trailing dot) domain to be rejected, got %v", err)
+	}
+}
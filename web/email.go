@@ -0,0 +1,236 @@
+package web
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/giantswarm/validate"
+)
+
+// An email address value to be validated.
+type Email struct {
+	email   []byte
+	message string
+	checks  map[string]interface{}
+
+	domainCfg *Domain
+
+	localPart string
+	domain    string
+}
+
+// Return the email address
+func (e *Email) String() string {
+	return string(e.email)
+}
+
+// Create a new email address value to be validated.
+func NewEmail(email string) *Email {
+	e := Email{
+		email:     []byte(email),
+		checks:    make(map[string]interface{}),
+		domainCfg: NewDomain(""),
+	}
+	return &e
+}
+
+// Sets the validation failure message.
+func (e *Email) SetMessage(msg string) validate.Method {
+	e.message = msg
+	return e
+}
+
+// Return the failed validation message.
+func (e *Email) Message() string {
+	return e.message
+}
+
+// Allows a quoted-string local-part, e.g. "\"john doe\"@example.com". By
+// default only the dot-atom form is accepted.
+func (e *Email) AllowQuotedLocal() *Email {
+	e.checks["quoted"] = true
+	return e
+}
+
+// Allows an address-literal domain, e.g. "user@[192.0.2.1]" or
+// "user@[IPv6:::1]". By default the domain must pass Domain validation.
+func (e *Email) AllowIPLiteral() *Email {
+	e.checks["ipliteral"] = true
+	return e
+}
+
+// Sets the *Domain used to validate the part after the "@", so IDNA, PSL,
+// and subdomain settings all compose with Email validation. Defaults to a
+// bare NewDomain. Validate works on a copy and leaves d unchanged, so one
+// *Domain can be shared by many Email values, including concurrently.
+func (e *Email) WithDomain(d *Domain) *Email {
+	e.domainCfg = d
+	return e
+}
+
+// Returns the local-part, e.g. "john" for "john@example.com". Only
+// populated after Validate has succeeded.
+func (e *Email) LocalPart() string {
+	return e.localPart
+}
+
+// Returns the domain part, e.g. "example.com" for "john@example.com", or
+// the bracketed literal for an address-literal domain. Only populated
+// after Validate has succeeded.
+func (e *Email) Domain() string {
+	return e.domain
+}
+
+var (
+	// The local-part is empty or exceeds the 64 octet cap.
+	ErrLocalPartLength = &validate.ValidatorError{
+		ErrLevel: validate.ErrInvalid,
+		Message:  errors.New("Invalid local-part length"),
+	}
+
+	// The local-part contains a character or construct that isn't allowed
+	// by the enabled local-part mode.
+	ErrLocalPartFormat = &validate.ValidatorError{
+		ErrLevel: validate.ErrInvalid,
+		Message:  errors.New("Invalid local-part formatting"),
+	}
+
+	// No "@" was found to separate the local-part from the domain.
+	ErrNoAtSign = &validate.ValidatorError{
+		ErrLevel: validate.ErrInvalid,
+		Message:  errors.New("Missing @"),
+	}
+)
+
+// atext holds the RFC 5321/5322 punctuation allowed in a dot-atom
+// local-part, in addition to ALPHA/DIGIT.
+const atext = "!#$%&'*+-/=?^_`{|}~"
+
+func isAtext(b byte) bool {
+	switch {
+	case b >= 'A' && b <= 'Z', b >= 'a' && b <= 'z', b >= '0' && b <= '9':
+		return true
+	}
+	return strings.IndexByte(atext, b) >= 0
+}
+
+// Checks for a valid email address. Validates the local-part per RFC
+// 5321/5322 (dot-atom by default, or a quoted string with
+// AllowQuotedLocal), caps lengths per RFC 5321, and delegates the domain
+// part to the configured *Domain (see WithDomain) unless it's an
+// address-literal accepted via AllowIPLiteral.
+func (e *Email) Validate(v validate.Validator) validate.Error {
+	p := e.email
+
+	if utf8.RuneCount(p) > 254 {
+		return ErrDomainLength
+	}
+
+	at := bytes.LastIndexByte(p, '@')
+	if at < 0 {
+		return ErrNoAtSign
+	}
+	local := p[:at]
+	domainPart := p[at+1:]
+
+	if len(local) < 1 || len(local) > 64 {
+		return ErrLocalPartLength
+	}
+
+	quoted := len(local) >= 2 && local[0] == '"' && local[len(local)-1] == '"'
+	if quoted {
+		if _, ok := e.checks["quoted"]; !ok {
+			return ErrLocalPartFormat
+		}
+		if err := validateQuotedLocal(local); err != nil {
+			return err
+		}
+	} else if err := validateDotAtom(local); err != nil {
+		return err
+	}
+
+	if len(domainPart) >= 2 && domainPart[0] == '[' && domainPart[len(domainPart)-1] == ']' {
+		if _, ok := e.checks["ipliteral"]; !ok {
+			return ErrFormatting
+		}
+		if !validateIPLiteral(domainPart[1 : len(domainPart)-1]) {
+			return ErrFormatting
+		}
+		e.localPart = string(local)
+		e.domain = string(domainPart)
+		return nil
+	}
+
+	// Validate a copy of domainCfg, not domainCfg itself: WithDomain is
+	// meant to let one configured *Domain be shared across many Email
+	// validations, and writing the address being checked straight
+	// through the shared pointer would both race under concurrent use
+	// and leave the wrong domain behind in the caller's Domain afterward.
+	d := *e.domainCfg
+	d.domain = domainPart
+	if err := d.Validate(v); err != nil {
+		return err
+	}
+
+	e.localPart = string(local)
+	e.domain = d.String()
+	return nil
+}
+
+// Validates local per the dot-atom form: one or more atext-only atoms
+// separated by single dots, with no leading, trailing, or doubled dots.
+func validateDotAtom(local []byte) validate.Error {
+	for _, atom := range bytes.Split(local, []byte(".")) {
+		if len(atom) < 1 {
+			return ErrLocalPartFormat
+		}
+		for i := 0; i < len(atom); {
+			r, size := utf8.DecodeRune(atom[i:])
+			if r == utf8.RuneError && size <= 1 {
+				return validate.ErrInvalidUTF8
+			}
+			if size != 1 || !isAtext(atom[i]) {
+				return ErrLocalPartFormat
+			}
+			i++
+		}
+	}
+	return nil
+}
+
+// Validates the contents of a quoted-string local-part (the surrounding
+// quotes are still present; local is at least the two quote bytes). Per
+// RFC 5321's Quoted-string grammar ("DQUOTE *QcontentSMTP DQUOTE"), the
+// quoted content is zero or more characters, so a bare "" is a valid,
+// if unusual, local-part.
+func validateQuotedLocal(local []byte) validate.Error {
+	inner := local[1 : len(local)-1]
+	for i := 0; i < len(inner); i++ {
+		switch c := inner[i]; {
+		case c == '\\':
+			i++
+			if i >= len(inner) {
+				return ErrLocalPartFormat
+			}
+		case c == '"', c < 0x20, c == 0x7f:
+			return ErrLocalPartFormat
+		}
+	}
+	return nil
+}
+
+// Validates the contents of a domain-literal (the surrounding "[" "]"
+// have already been stripped off by the caller), accepting either an
+// IPv4 literal or an "IPv6:" prefixed IPv6 literal.
+func validateIPLiteral(lit []byte) bool {
+	s := string(lit)
+	if strings.HasPrefix(s, "IPv6:") {
+		ip := net.ParseIP(strings.TrimPrefix(s, "IPv6:"))
+		return ip != nil && ip.To4() == nil
+	}
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() != nil
+}
@@ -0,0 +1,109 @@
+package web
+
+import (
+	"sync"
+	"testing"
+)
+
+func Test_Email_IPLiteral(t *testing.T) {
+	e := NewEmail("user@[192.0.2.1]").AllowIPLiteral()
+	if err := e.Validate(nil); err != nil {
+		t.Fatalf("expected user@[192.0.2.1] to validate, got %v", err)
+	}
+	if e.LocalPart() != "user" {
+		t.Errorf("expected local-part user, got %s", e.LocalPart())
+	}
+	if e.Domain() != "[192.0.2.1]" {
+		t.Errorf("expected domain [192.0.2.1], got %s", e.Domain())
+	}
+}
+
+func Test_Email_IPLiteralRejectedByDefault(t *testing.T) {
+	e := NewEmail("user@[192.0.2.1]")
+	if err := e.Validate(nil); err == nil {
+		t.Error("expected user@[192.0.2.1] to be rejected without AllowIPLiteral")
+	}
+}
+
+func Test_Email_NoAtSign(t *testing.T) {
+	e := NewEmail("userexample.com")
+	if err := e.Validate(nil); err != ErrNoAtSign {
+		t.Errorf("expected ErrNoAtSign, got %v", err)
+	}
+}
+
+func Test_Email_QuotedLocal(t *testing.T) {
+	e := NewEmail(`"john doe"@example.com`).AllowQuotedLocal()
+	if err := e.Validate(nil); err != nil {
+		t.Fatalf(`expected "john doe"@example.com to validate, got %v`, err)
+	}
+	if e.LocalPart() != `"john doe"` {
+		t.Errorf(`expected local-part "john doe" (with quotes), got %s`, e.LocalPart())
+	}
+}
+
+func Test_Email_QuotedLocal_EscapedQuote(t *testing.T) {
+	e := NewEmail(`"john\"doe"@example.com`).AllowQuotedLocal()
+	if err := e.Validate(nil); err != nil {
+		t.Fatalf(`expected escaped-quote quoted local to validate, got %v`, err)
+	}
+}
+
+func Test_Email_QuotedLocal_RejectedByDefault(t *testing.T) {
+	e := NewEmail(`"john doe"@example.com`)
+	if err := e.Validate(nil); err != ErrLocalPartFormat {
+		t.Errorf("expected ErrLocalPartFormat without AllowQuotedLocal, got %v", err)
+	}
+}
+
+// RFC 5321's quoted-string grammar allows zero-length content; an empty
+// quoted local-part is therefore an intentional pass, not an oversight.
+func Test_Email_QuotedLocal_EmptyIsAllowed(t *testing.T) {
+	e := NewEmail(`""@example.com`).AllowQuotedLocal()
+	if err := e.Validate(nil); err != nil {
+		t.Errorf(`expected ""@example.com to validate, got %v`, err)
+	}
+}
+
+// A single, shared *Domain (as WithDomain is documented to support) must
+// not be corrupted by concurrent Email validations against it.
+func Test_Email_WithDomain_SharedDomainNotMutated(t *testing.T) {
+	shared := NewDomain("")
+
+	e1 := NewEmail("alice@example.com").WithDomain(shared)
+	if err := e1.Validate(nil); err != nil {
+		t.Fatalf("expected alice@example.com to validate, got %v", err)
+	}
+	if shared.String() != "" {
+		t.Errorf("expected shared Domain to be untouched after Validate, got %q", shared.String())
+	}
+
+	e2 := NewEmail("bob@example.org").WithDomain(shared)
+	if err := e2.Validate(nil); err != nil {
+		t.Fatalf("expected bob@example.org to validate, got %v", err)
+	}
+
+	if e1.Domain() != "example.com" {
+		t.Errorf("expected e1 domain example.com, got %s", e1.Domain())
+	}
+	if e2.Domain() != "example.org" {
+		t.Errorf("expected e2 domain example.org, got %s", e2.Domain())
+	}
+}
+
+func Test_Email_WithDomain_ConcurrentUseIsRaceFree(t *testing.T) {
+	shared := NewDomain("")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e := NewEmail("user@example.com").WithDomain(shared)
+			if err := e.Validate(nil); err != nil {
+				t.Errorf("expected user@example.com to validate, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,122 @@
+package web
+
+import (
+	"bytes"
+	"errors"
+	"github.com/giantswarm/validate"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A hostname value to be validated
+type Hostname struct {
+	hostname []byte
+	message  string
+}
+
+// Return the hostname
+func (h *Hostname) String() string {
+	return string(h.hostname)
+}
+
+// Create a new hostname value to be validated
+func NewHostname(hostname string) *Hostname {
+	h := Hostname{
+		hostname: []byte(hostname),
+	}
+	return &h
+}
+
+// Sets the validation failure message.
+func (h *Hostname) SetMessage(msg string) validate.Method {
+	h.message = msg
+	return h
+}
+
+// Return the failed validation message.
+func (h *Hostname) Message() string {
+	return h.message
+}
+
+var (
+	// Every label of the hostname is numeric-only, as in an IPv4 literal.
+	ErrNumericHostname = &validate.ValidatorError{
+		ErrLevel: validate.ErrInvalid,
+		Message:  errors.New("Hostname cannot be all numeric"),
+	}
+)
+
+// Checks for a valid hostname per the RFC 1123 hostname profile. Unlike
+// Domain, a single label is allowed (no TLD is required), but a purely
+// numeric hostname is rejected so that an IPv4 literal like "10.0.0.1"
+// doesn't pass as a hostname.
+func (h *Hostname) Validate(v validate.Validator) validate.Error {
+	// Hostname rules:
+	// - 253 character total length max
+	// - 63 character label max
+	// - Characters a-z, A-Z, 0-9, and -
+	// - Labels may not start or end with -
+	// - Hostname may not be entirely numeric
+
+	p := h.hostname
+	if utf8.RuneCount(p) > 253 {
+		return ErrDomainLength
+	}
+
+	labels := bytes.Split(p, []byte("."))
+
+	allNumeric := true
+	for _, label := range labels {
+		length := len(label)
+		if length < 1 {
+			return ErrFormatting
+		}
+		if length > 63 {
+			return ErrDomainLength
+		}
+
+		if !isAllDigits(label) {
+			allNumeric = false
+		}
+
+		r, size := utf8.DecodeRune(label)
+		if r == utf8.RuneError && size == 1 {
+			return validate.ErrInvalidUTF8
+		}
+		if r == '-' {
+			return ErrFormatting
+		}
+
+		r, size = utf8.DecodeLastRune(label)
+		if r == utf8.RuneError && size == 1 {
+			return validate.ErrInvalidUTF8
+		}
+		if r == '-' {
+			return ErrFormatting
+		}
+
+		for i := 0; i < length; {
+			if label[i] < utf8.RuneSelf {
+				if !unicode.Is(domainTable, rune(label[i])) {
+					return ErrFormatting
+				}
+				i++
+			} else {
+				r, size := utf8.DecodeRune(label[i:])
+				if size == 1 {
+					return validate.ErrInvalidUTF8
+				}
+				if !unicode.Is(domainTable, r) {
+					return ErrFormatting
+				}
+				i += size
+			}
+		}
+	}
+
+	if allNumeric {
+		return ErrNumericHostname
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package web
+
+import "testing"
+
+func Test_Hostname_RejectsIPv4Literal(t *testing.T) {
+	h := NewHostname("10.0.0.1")
+	if err := h.Validate(nil); err != ErrNumericHostname {
+		t.Errorf("expected ErrNumericHostname for 10.0.0.1, got %v", err)
+	}
+}
+
+func Test_Hostname_AllowsSingleLabel(t *testing.T) {
+	h := NewHostname("localhost")
+	if err := h.Validate(nil); err != nil {
+		t.Errorf("expected localhost to validate, got %v", err)
+	}
+}
+
+func Test_Domain_Wildcard(t *testing.T) {
+	d := NewDomain("*.example.com").AllowWildcard()
+	if err := d.Validate(nil); err != nil {
+		t.Errorf("expected *.example.com to validate, got %v", err)
+	}
+}
+
+func Test_Domain_WildcardRejectedByDefault(t *testing.T) {
+	d := NewDomain("*.example.com")
+	if err := d.Validate(nil); err == nil {
+		t.Error("expected *.example.com to be rejected without AllowWildcard")
+	}
+}
+
+func Test_Domain_WildcardOnlyLeftmost(t *testing.T) {
+	d := NewDomain("foo.*.com").AllowWildcard()
+	if err := d.Validate(nil); err != ErrWildcardPlacement {
+		t.Errorf("expected ErrWildcardPlacement, got %v", err)
+	}
+}
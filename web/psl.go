@@ -0,0 +1,66 @@
+package web
+
+import (
+	"github.com/giantswarm/validate"
+	"golang.org/x/net/publicsuffix"
+	"strings"
+)
+
+// PSLSource resolves the public suffix of a domain, giving access to
+// Public Suffix List data beyond the plain IANA TLD check. A PSLSource can
+// be backed by golang.org/x/net/publicsuffix's compiled-in table, or load
+// Mozilla's public_suffix_list.dat directly for a custom refresh cycle.
+type PSLSource interface {
+	// PublicSuffix returns the public suffix of domain and whether it is
+	// managed by ICANN (false indicates the PRIVATE DOMAINS section, e.g.
+	// "github.io").
+	PublicSuffix(domain string) (suffix string, icann bool)
+}
+
+// DefaultPSLSource is the package-default PSLSource, backed by
+// golang.org/x/net/publicsuffix.
+var DefaultPSLSource PSLSource = publicSuffixSource{}
+
+type publicSuffixSource struct{}
+
+func (publicSuffixSource) PublicSuffix(domain string) (string, bool) {
+	return publicsuffix.PublicSuffix(domain)
+}
+
+// classifyPSL runs PSL classification over the validated domain p (the
+// A-label form, or the Unicode form under idna.Display) when any of RequireRegistrable, ForbidPrivateSuffix, or WithPSLSource
+// have been set, populating publicSuffix, registrable, and subdomains.
+func (d *Domain) classifyPSL(p []byte) validate.Error {
+	_, requireRegistrable := d.checks["requireregistrable"]
+	_, forbidPrivate := d.checks["forbidprivate"]
+	src, hasSource := d.checks["pslsource"].(PSLSource)
+	if !requireRegistrable && !forbidPrivate && !hasSource {
+		return nil
+	}
+	if !hasSource {
+		src = DefaultPSLSource
+	}
+
+	full := strings.ToLower(string(p))
+	suffix, icann := src.PublicSuffix(full)
+	d.publicSuffix = suffix
+
+	if !icann && forbidPrivate {
+		return ErrPrivateSuffix
+	}
+
+	if full == suffix {
+		if requireRegistrable {
+			return ErrNotRegistrable
+		}
+		d.registrable = ""
+		d.subdomains = nil
+		return nil
+	}
+
+	rest := strings.TrimSuffix(full, "."+suffix)
+	labels := strings.Split(rest, ".")
+	d.registrable = labels[len(labels)-1] + "." + suffix
+	d.subdomains = labels[:len(labels)-1]
+	return nil
+}
@@ -0,0 +1,41 @@
+package web
+
+import "testing"
+
+func Test_Domain_RequireRegistrable_RejectsBareSuffix(t *testing.T) {
+	d := NewDomain("co.uk").RequireRegistrable()
+	if err := d.Validate(nil); err != ErrNotRegistrable {
+		t.Errorf("expected ErrNotRegistrable for co.uk, got %v", err)
+	}
+}
+
+func Test_Domain_RequireRegistrable_AllowsRegistrable(t *testing.T) {
+	d := NewDomain("example.co.uk").RequireRegistrable()
+	if err := d.Validate(nil); err != nil {
+		t.Fatalf("expected example.co.uk to validate, got %v", err)
+	}
+	if d.RegistrableDomain() != "example.co.uk" {
+		t.Errorf("expected registrable domain example.co.uk, got %s", d.RegistrableDomain())
+	}
+	if d.PublicSuffix() != "co.uk" {
+		t.Errorf("expected public suffix co.uk, got %s", d.PublicSuffix())
+	}
+}
+
+func Test_Domain_ForbidPrivateSuffix(t *testing.T) {
+	d := NewDomain("foo.github.io").ForbidPrivateSuffix()
+	if err := d.Validate(nil); err != ErrPrivateSuffix {
+		t.Errorf("expected ErrPrivateSuffix for foo.github.io, got %v", err)
+	}
+}
+
+func Test_Domain_Subdomains(t *testing.T) {
+	d := NewDomain("www.example.co.uk").RequireRegistrable()
+	if err := d.Validate(nil); err != nil {
+		t.Fatalf("expected www.example.co.uk to validate, got %v", err)
+	}
+	subs := d.Subdomains()
+	if len(subs) != 1 || subs[0] != "www" {
+		t.Errorf("expected subdomains [www], got %v", subs)
+	}
+}
@@ -0,0 +1,232 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/inhies/go-tld"
+	"golang.org/x/net/publicsuffix"
+)
+
+// TLDSource supplies the set of valid top-level domains used by
+// Domain.Validate. The package-level IANA list (see IANA, TLDs, and
+// UpdateTLDs) remains the default for back-compat; use Domain.WithTLDSource
+// to give an individual Domain its own source instead.
+type TLDSource interface {
+	// Valid reports whether label (a single domain label, e.g. "com") is
+	// a recognized TLD.
+	Valid(label []byte) bool
+}
+
+// IANASource is the historical behavior: it delegates to
+// github.com/inhies/go-tld's package-level, IANA-backed list.
+type IANASource struct{}
+
+func (IANASource) Valid(label []byte) bool {
+	return tld.Valid(label)
+}
+
+// StaticSource is a fixed, caller-supplied set of TLDs. It's safe for
+// concurrent use; Set atomically replaces the set so a refresh never
+// races with a validation in progress.
+type StaticSource struct {
+	set atomic.Pointer[map[string]struct{}]
+}
+
+// NewStaticSource builds a StaticSource from the given TLDs (case-insensitive).
+func NewStaticSource(tlds []string) *StaticSource {
+	s := &StaticSource{}
+	s.Set(tlds)
+	return s
+}
+
+func (s *StaticSource) Valid(label []byte) bool {
+	set := s.set.Load()
+	if set == nil {
+		return false
+	}
+	_, ok := (*set)[strings.ToLower(string(label))]
+	return ok
+}
+
+// Set atomically replaces the TLD set.
+func (s *StaticSource) Set(tlds []string) {
+	m := make(map[string]struct{}, len(tlds))
+	for _, t := range tlds {
+		m[strings.ToLower(t)] = struct{}{}
+	}
+	s.set.Store(&m)
+}
+
+// PSLTLDSource treats Mozilla's Public Suffix List, via
+// golang.org/x/net/publicsuffix, as the source of valid TLDs: a label is
+// valid if it is itself a recognized public suffix. Named distinctly from
+// the PSLSource used for RequireRegistrable/ForbidPrivateSuffix
+// classification, since the two serve different questions (is this a
+// known TLD? vs. what's the registrable domain?).
+type PSLTLDSource struct{}
+
+func (PSLTLDSource) Valid(label []byte) bool {
+	l := strings.ToLower(string(label))
+	// publicsuffix treats any unlisted TLD as its own (non-ICANN) suffix,
+	// so only an ICANN-managed match counts as recognized.
+	suffix, icann := publicsuffix.PublicSuffix(l)
+	return suffix == l && icann
+}
+
+// CachedSource wraps an Upstream fetch function behind an atomically
+// swapped in-memory set, so a refresh never races with a validation. When
+// no set is loaded yet, or the set is stale, Valid runs Refresh itself and
+// waits for it. If Path is set, a successful refresh is persisted
+// there (one TLD per line) so a process restart can seed itself from disk
+// before the first refresh completes.
+type CachedSource struct {
+	// Upstream fetches the current full list of TLDs, e.g. by downloading
+	// and parsing an IANA or PSL snapshot.
+	Upstream func() ([]string, error)
+	// TTL is how long a successful Refresh is trusted before Valid
+	// triggers another one on access, and the default interval
+	// StartAutoRefresh uses when called with interval <= 0.
+	TTL  time.Duration
+	Path string
+
+	set         atomic.Pointer[map[string]struct{}]
+	loadedFS    int32        // 1 once an attempt to seed from Path has been made
+	lastRefresh atomic.Int64 // UnixNano of the last successful Refresh
+}
+
+func (c *CachedSource) Valid(label []byte) bool {
+	set := c.set.Load()
+	if set == nil && c.Path != "" && atomic.CompareAndSwapInt32(&c.loadedFS, 0, 1) {
+		if tlds, err := readTLDFile(c.Path); err == nil {
+			c.store(tlds)
+			set = c.set.Load()
+		}
+	}
+	if set == nil || c.stale() {
+		// Best-effort: on failure, fall back to whatever is cached
+		// (possibly nothing, in which case every label is invalid).
+		if err := c.Refresh(context.Background()); err == nil {
+			set = c.set.Load()
+		}
+	}
+	if set == nil {
+		return false
+	}
+	_, ok := (*set)[strings.ToLower(string(label))]
+	return ok
+}
+
+// stale reports whether the last successful Refresh is older than TTL. A
+// zero TTL means a loaded set never goes stale; once one is loaded,
+// StartAutoRefresh (or a manual Refresh call) is then the only way to pick
+// up new data.
+func (c *CachedSource) stale() bool {
+	if c.TTL <= 0 {
+		return false
+	}
+	last := c.lastRefresh.Load()
+	return last == 0 || time.Since(time.Unix(0, last)) > c.TTL
+}
+
+func (c *CachedSource) store(tlds []string) {
+	m := make(map[string]struct{}, len(tlds))
+	for _, t := range tlds {
+		m[strings.ToLower(t)] = struct{}{}
+	}
+	c.set.Store(&m)
+	c.lastRefresh.Store(time.Now().UnixNano())
+}
+
+// Refresh fetches a fresh TLD list from Upstream and atomically swaps it
+// in, persisting to Path afterward if one is set.
+func (c *CachedSource) Refresh(ctx context.Context) error {
+	tlds, err := c.Upstream()
+	if err != nil {
+		return err
+	}
+	c.store(tlds)
+	if c.Path != "" {
+		return writeTLDFile(c.Path, tlds)
+	}
+	return nil
+}
+
+// StartAutoRefresh runs Refresh every interval until ctx is canceled,
+// backing off exponentially (capped at 1 hour) after consecutive
+// failures and resetting to interval on the next success. If interval is
+// <= 0, TTL is used instead; if both are <= 0, StartAutoRefresh is a
+// no-op since there's no sensible cadence to run on. If onUpdate is
+// non-nil, it's called after every attempt with the resulting error (nil
+// on success).
+func (c *CachedSource) StartAutoRefresh(ctx context.Context, interval time.Duration, onUpdate func(err error)) {
+	if interval <= 0 {
+		interval = c.TTL
+	}
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		const maxBackoff = time.Hour
+		backoff := interval
+		for {
+			err := c.Refresh(ctx)
+			if onUpdate != nil {
+				onUpdate(err)
+			}
+			if err != nil {
+				backoff *= 2
+				if backoff > maxBackoff {
+					backoff = maxBackoff
+				}
+			} else {
+				backoff = interval
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+		}
+	}()
+}
+
+func readTLDFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var tlds []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		tlds = append(tlds, line)
+	}
+	return tlds, scanner.Err()
+}
+
+func writeTLDFile(path string, tlds []string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	for _, t := range tlds {
+		if _, err := w.WriteString(t + "\n"); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
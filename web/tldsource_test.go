@@ -0,0 +1,75 @@
+package web
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_StaticSource(t *testing.T) {
+	s := NewStaticSource([]string{"com", "Org"})
+	if !s.Valid([]byte("com")) {
+		t.Error("expected com to be valid")
+	}
+	if !s.Valid([]byte("org")) {
+		t.Error("expected org to be valid case-insensitively")
+	}
+	if s.Valid([]byte("zzz")) {
+		t.Error("expected zzz to be invalid")
+	}
+}
+
+func Test_CachedSource_RefreshesOnTTLExpiry(t *testing.T) {
+	var calls int32
+	c := &CachedSource{
+		TTL: 10 * time.Millisecond,
+		Upstream: func() ([]string, error) {
+			atomic.AddInt32(&calls, 1)
+			return []string{"com"}, nil
+		},
+	}
+
+	if !c.Valid([]byte("com")) {
+		t.Fatal("expected com to be valid after first fetch")
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Fatalf("expected 1 upstream call, got %d", n)
+	}
+
+	// Within TTL, Valid should not refetch.
+	c.Valid([]byte("com"))
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Fatalf("expected still 1 upstream call within TTL, got %d", n)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	c.Valid([]byte("com"))
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Fatalf("expected a second upstream call after TTL expiry, got %d", n)
+	}
+}
+
+func Test_CachedSource_StartAutoRefresh_DefaultsToTTL(t *testing.T) {
+	done := make(chan struct{}, 1)
+	c := &CachedSource{
+		TTL: 5 * time.Millisecond,
+		Upstream: func() ([]string, error) {
+			return []string{"com"}, nil
+		},
+	}
+
+	c.StartAutoRefresh(context.Background(), 0, func(err error) {
+		select {
+		case done <- struct{}{}:
+		default:
+		}
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected StartAutoRefresh to run using TTL as the default interval")
+	}
+}